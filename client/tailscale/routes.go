@@ -11,22 +11,77 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/netip"
+	"sort"
+	"sync"
+	"time"
 
 	"inet.af/netaddr"
+	"tailscale.com/util/multierr"
 )
 
+// exitNodeV4Route and exitNodeV6Route are the special subnet routes that
+// designate a device as an exit node for IPv4 and IPv6 traffic,
+// respectively.
+var (
+	exitNodeV4Route = netaddr.MustParseIPPrefix("0.0.0.0/0")
+	exitNodeV6Route = netaddr.MustParseIPPrefix("::/0")
+)
+
+// ErrExitNodeMismatch is returned by Client.SetExitNode when the caller
+// asks to enable or disable only one of the IPv4 and IPv6 default routes.
+// Exit node routing is treated atomically across both address families.
+var ErrExitNodeMismatch = errors.New("tailscale: exit node routes must be enabled or disabled for both IPv4 and IPv6 together")
+
+// ErrRouteNotAdvertised is returned by Client.UpdateRoutes when
+// RouteMutation.RequireAdvertised is set and RouteMutation.Enable contains
+// a prefix the device does not advertise.
+var ErrRouteNotAdvertised = errors.New("tailscale: route is not advertised by device")
+
 // Routes contains the lists of subnet routes that are currently advertised by a device,
 // as well as the subnets that are enabled to be routed by the device.
 type Routes struct {
 	AdvertisedRoutes []netaddr.IPPrefix `json:"advertisedRoutes"`
 	EnabledRoutes    []netaddr.IPPrefix `json:"enabledRoutes"`
+	// PrimaryRoutes lists the subnets for which this device is currently
+	// the primary subnet router, as designated by the control plane. A
+	// device can advertise a subnet without being primary for it, when
+	// another device already holds that route.
+	PrimaryRoutes []netaddr.IPPrefix `json:"primaryRoutes,omitempty"`
+}
+
+// AdvertisedExitNode reports whether the device advertises itself as an
+// exit node, i.e. whether it advertises both the IPv4 and IPv6 default
+// routes.
+func (r *Routes) AdvertisedExitNode() bool {
+	return containsPrefix(r.AdvertisedRoutes, exitNodeV4Route) && containsPrefix(r.AdvertisedRoutes, exitNodeV6Route)
+}
+
+// EnabledExitNode reports whether the device is enabled to act as an exit
+// node, i.e. whether both the IPv4 and IPv6 default routes are enabled.
+func (r *Routes) EnabledExitNode() bool {
+	return containsPrefix(r.EnabledRoutes, exitNodeV4Route) && containsPrefix(r.EnabledRoutes, exitNodeV6Route)
+}
+
+func containsPrefix(routes []netaddr.IPPrefix, p netaddr.IPPrefix) bool {
+	for _, r := range routes {
+		if r == p {
+			return true
+		}
+	}
+	return false
 }
 
 // Routes retrieves the list of subnet routes that have been enabled for a device.
 // The routes that are returned are not necessarily advertised by the device,
 // they have only been preapproved.
+//
+// Deprecated: use RoutesNetip instead. inet.af/netaddr is archived in
+// favor of the standard library's net/netip, and this method is now a
+// thin wrapper around RoutesNetip.
 func (c *Client) Routes(ctx context.Context, deviceID string) (routes *Routes, err error) {
 	defer func() {
 		if err != nil {
@@ -34,6 +89,62 @@ func (c *Client) Routes(ctx context.Context, deviceID string) (routes *Routes, e
 		}
 	}()
 
+	rv2, err := c.RoutesNetip(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	return routesFromV2(rv2)
+}
+
+// SetRoutes updates the list of subnets that are enabled for a device.
+// Subnets must be parsable by inet.af/netaddr.ParseIPPrefix.
+// Subnets do not have to be currently advertised by a device, they may be pre-enabled.
+// Returns the updated list of enabled and advertised subnet routes in a *Routes object.
+//
+// Deprecated: use SetRoutesNetip instead. inet.af/netaddr is archived in
+// favor of the standard library's net/netip, and this method is now a
+// thin wrapper around SetRoutesNetip.
+func (c *Client) SetRoutes(ctx context.Context, deviceID string, subnets []netaddr.IPPrefix) (routes *Routes, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("tailscale.SetRoutes: %w", err)
+		}
+	}()
+
+	netipSubnets, err := ipPrefixesToNetip(subnets)
+	if err != nil {
+		return nil, err
+	}
+	rv2, err := c.SetRoutesNetip(ctx, deviceID, netipSubnets)
+	if err != nil {
+		return nil, err
+	}
+	return routesFromV2(rv2)
+}
+
+// RoutesV2 is the net/netip analog of Routes. Its JSON representation is
+// wire-compatible with Routes, since both inet.af/netaddr.IPPrefix and
+// net/netip.Prefix marshal to and parse from the same CIDR string form.
+type RoutesV2 struct {
+	AdvertisedRoutes []netip.Prefix `json:"advertisedRoutes"`
+	EnabledRoutes    []netip.Prefix `json:"enabledRoutes"`
+	// PrimaryRoutes lists the subnets for which this device is currently
+	// the primary subnet router, as designated by the control plane. A
+	// device can advertise a subnet without being primary for it, when
+	// another device already holds that route.
+	PrimaryRoutes []netip.Prefix `json:"primaryRoutes,omitempty"`
+}
+
+// RoutesNetip retrieves the list of subnet routes that have been enabled
+// for a device, like Routes, but using the standard library's
+// net/netip.Prefix instead of the archived inet.af/netaddr.IPPrefix.
+func (c *Client) RoutesNetip(ctx context.Context, deviceID string) (routes *RoutesV2, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("tailscale.RoutesNetip: %w", err)
+		}
+	}()
+
 	path := fmt.Sprintf("%s/api/v2/device/%s/routes", c.baseURL(), deviceID)
 	req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
 	if err != nil {
@@ -50,26 +161,28 @@ func (c *Client) Routes(ctx context.Context, deviceID string) (routes *Routes, e
 		return nil, handleErrorResponse(b, resp)
 	}
 
-	var sr Routes
+	var sr RoutesV2
 	err = json.Unmarshal(b, &sr)
 	return &sr, err
 }
 
-type postRoutesParams struct {
-	Routes []netaddr.IPPrefix `json:"routes"`
+type postRoutesV2Params struct {
+	Routes []netip.Prefix `json:"routes"`
 }
 
-// SetRoutes updates the list of subnets that are enabled for a device.
-// Subnets must be parsable by inet.af/netaddr.ParseIPPrefix.
-// Subnets do not have to be currently advertised by a device, they may be pre-enabled.
-// Returns the updated list of enabled and advertised subnet routes in a *Routes object.
-func (c *Client) SetRoutes(ctx context.Context, deviceID string, subnets []netaddr.IPPrefix) (routes *Routes, err error) {
+// SetRoutesNetip updates the list of subnets that are enabled for a
+// device, like SetRoutes, but using the standard library's
+// net/netip.Prefix instead of the archived inet.af/netaddr.IPPrefix.
+// Subnets do not have to be currently advertised by a device, they may be
+// pre-enabled. Returns the updated list of enabled and advertised subnet
+// routes in a *RoutesV2 object.
+func (c *Client) SetRoutesNetip(ctx context.Context, deviceID string, subnets []netip.Prefix) (routes *RoutesV2, err error) {
 	defer func() {
 		if err != nil {
-			err = fmt.Errorf("tailscale.SetRoutes: %w", err)
+			err = fmt.Errorf("tailscale.SetRoutesNetip: %w", err)
 		}
 	}()
-	params := &postRoutesParams{Routes: subnets}
+	params := &postRoutesV2Params{Routes: subnets}
 	data, err := json.Marshal(params)
 	if err != nil {
 		return nil, err
@@ -90,9 +203,804 @@ func (c *Client) SetRoutes(ctx context.Context, deviceID string, subnets []netad
 		return nil, handleErrorResponse(b, resp)
 	}
 
-	var srr *Routes
+	var srr *RoutesV2
 	if err := json.Unmarshal(b, &srr); err != nil {
 		return nil, err
 	}
 	return srr, err
-}
\ No newline at end of file
+}
+
+// routesFromV2 converts a RoutesV2 to the equivalent Routes, for
+// backward-compatible callers of the deprecated netaddr-based API.
+func routesFromV2(r *RoutesV2) (*Routes, error) {
+	if r == nil {
+		return nil, nil
+	}
+	advertised, err := netipPrefixesToIPPrefixes(r.AdvertisedRoutes)
+	if err != nil {
+		return nil, err
+	}
+	enabled, err := netipPrefixesToIPPrefixes(r.EnabledRoutes)
+	if err != nil {
+		return nil, err
+	}
+	primary, err := netipPrefixesToIPPrefixes(r.PrimaryRoutes)
+	if err != nil {
+		return nil, err
+	}
+	return &Routes{
+		AdvertisedRoutes: advertised,
+		EnabledRoutes:    enabled,
+		PrimaryRoutes:    primary,
+	}, nil
+}
+
+// ipPrefixesToNetip converts inet.af/netaddr prefixes to their net/netip
+// equivalents. It returns an error if a prefix fails to round-trip
+// through its CIDR string form; the two packages are expected to agree,
+// but a caller-supplied netaddr.IPPrefix need not be well-formed.
+func ipPrefixesToNetip(ps []netaddr.IPPrefix) ([]netip.Prefix, error) {
+	if ps == nil {
+		return nil, nil
+	}
+	out := make([]netip.Prefix, len(ps))
+	for i, p := range ps {
+		np, err := netip.ParsePrefix(p.String())
+		if err != nil {
+			return nil, fmt.Errorf("tailscale: netaddr prefix %q did not round-trip to netip: %w", p, err)
+		}
+		out[i] = np
+	}
+	return out, nil
+}
+
+// netipPrefixesToIPPrefixes converts net/netip prefixes to their
+// inet.af/netaddr equivalents. It returns an error if a prefix fails to
+// round-trip through its CIDR string form; the two packages are expected
+// to agree, but a caller-supplied netip.Prefix need not be well-formed.
+func netipPrefixesToIPPrefixes(ps []netip.Prefix) ([]netaddr.IPPrefix, error) {
+	if ps == nil {
+		return nil, nil
+	}
+	out := make([]netaddr.IPPrefix, len(ps))
+	for i, p := range ps {
+		ap, err := netaddr.ParseIPPrefix(p.String())
+		if err != nil {
+			return nil, fmt.Errorf("tailscale: netip prefix %q did not round-trip to netaddr: %w", p, err)
+		}
+		out[i] = ap
+	}
+	return out, nil
+}
+
+// PrimaryRouteInfo describes, for a single advertised subnet, the set of
+// devices advertising it and which one of them is primary.
+type PrimaryRouteInfo struct {
+	Prefix netaddr.IPPrefix
+
+	// Devices is the sorted list of device IDs currently advertising Prefix.
+	Devices []string
+
+	// PrimaryID is the device ID that is primary for Prefix. It is empty
+	// if no device advertises Prefix.
+	PrimaryID string
+}
+
+// PrimaryRoutes reports which devices in the tailnet advertise prefix and
+// which one of them is currently primary. It aggregates per-device Routes
+// calls, since the API has no single endpoint for this, and deduplicates
+// devices advertising the same prefix. If no device's Routes designates a
+// primary for prefix, the device with the lowest ID is chosen
+// deterministically so callers can build failover tooling on top of a
+// stable answer.
+func (c *Client) PrimaryRoutes(ctx context.Context, prefix netaddr.IPPrefix) (info *PrimaryRouteInfo, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("tailscale.PrimaryRoutes: %w", err)
+		}
+	}()
+
+	devices, err := c.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var advertising, primary []string
+	for _, d := range devices {
+		routes, err := c.Routes(ctx, d.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range routes.AdvertisedRoutes {
+			if r == prefix {
+				advertising = append(advertising, d.ID)
+				break
+			}
+		}
+		for _, r := range routes.PrimaryRoutes {
+			if r == prefix {
+				primary = append(primary, d.ID)
+				break
+			}
+		}
+	}
+	sort.Slice(advertising, func(i, j int) bool { return deviceIDLess(advertising[i], advertising[j]) })
+
+	info = &PrimaryRouteInfo{Prefix: prefix, Devices: advertising}
+	switch {
+	case len(primary) > 0:
+		sort.Slice(primary, func(i, j int) bool { return deviceIDLess(primary[i], primary[j]) })
+		info.PrimaryID = primary[0]
+	case len(advertising) > 0:
+		info.PrimaryID = advertising[0]
+	}
+	return info, nil
+}
+
+// deviceIDLess reports whether device ID a numerically precedes b.
+// Tailscale device IDs are non-negative decimal numeric strings that can
+// exceed the range of a machine integer, so comparing them
+// lexicographically (as sort.Strings does) is wrong: "2" would sort after
+// "10". Comparing digit count first, then falling back to a
+// lexicographic comparison of equal-length IDs, gives the correct
+// numeric order without needing to parse into a big integer type.
+func deviceIDLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+type postPrimaryRouteParams struct {
+	Prefix netaddr.IPPrefix `json:"prefix"`
+}
+
+// SetPrimaryRoute explicitly promotes deviceID to be the primary subnet
+// router for prefix, overriding whatever the control plane would otherwise
+// choose. deviceID must already be advertising prefix.
+func (c *Client) SetPrimaryRoute(ctx context.Context, deviceID string, prefix netaddr.IPPrefix) (routes *Routes, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("tailscale.SetPrimaryRoute: %w", err)
+		}
+	}()
+
+	params := &postPrimaryRouteParams{Prefix: prefix}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("%s/api/v2/device/%s/routes/primary", c.baseURL(), deviceID)
+	req, err := http.NewRequestWithContext(ctx, "POST", path, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+
+	b, resp, err := c.sendRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	// If status code was not successful, return the error.
+	// TODO: Change the check for the StatusCode to include other 2XX success codes.
+	if resp.StatusCode != http.StatusOK {
+		return nil, handleErrorResponse(b, resp)
+	}
+
+	var rr *Routes
+	if err := json.Unmarshal(b, &rr); err != nil {
+		return nil, err
+	}
+	return rr, nil
+}
+
+// SetExitNode enables or disables deviceID as an exit node by adding or
+// removing the 0.0.0.0/0 and ::/0 default routes from its enabled routes,
+// without disturbing any other enabled subnet routes. enableV4 and
+// enableV6 must agree, as exit node routing is all-or-nothing across both
+// address families; a mismatched pair returns ErrExitNodeMismatch.
+func (c *Client) SetExitNode(ctx context.Context, deviceID string, enableV4, enableV6 bool) (routes *Routes, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("tailscale.SetExitNode: %w", err)
+		}
+	}()
+
+	if enableV4 != enableV6 {
+		return nil, ErrExitNodeMismatch
+	}
+
+	current, err := c.Routes(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := make([]netaddr.IPPrefix, 0, len(current.EnabledRoutes)+2)
+	for _, r := range current.EnabledRoutes {
+		if r == exitNodeV4Route || r == exitNodeV6Route {
+			continue
+		}
+		merged = append(merged, r)
+	}
+	if enableV4 {
+		merged = append(merged, exitNodeV4Route, exitNodeV6Route)
+	}
+
+	return c.SetRoutes(ctx, deviceID, merged)
+}
+
+// DeviceRoutes pairs a device's ID and hostname with its current Routes.
+type DeviceRoutes struct {
+	DeviceID string
+	Hostname string
+	Routes   Routes
+}
+
+// TailnetRoutesOptions filters the results of Client.TailnetRoutes. The
+// zero value matches every device.
+type TailnetRoutesOptions struct {
+	// AdvertisedOnly, if true, only includes devices that advertise at
+	// least one subnet route.
+	AdvertisedOnly bool
+	// EnabledOnly, if true, only includes devices that have at least one
+	// subnet route enabled.
+	EnabledOnly bool
+	// ContainsPrefix, if non-zero, only includes devices that advertise or
+	// have enabled this exact prefix.
+	ContainsPrefix netaddr.IPPrefix
+	// ExitNodesOnly, if true, only includes devices advertising themselves
+	// as an exit node.
+	ExitNodesOnly bool
+
+	// MaxConcurrency bounds the number of concurrent per-device Routes
+	// calls. Zero or negative means defaultTailnetRoutesConcurrency.
+	MaxConcurrency int
+}
+
+// defaultTailnetRoutesConcurrency is the worker pool size used by
+// TailnetRoutes when TailnetRoutesOptions.MaxConcurrency is unset.
+const defaultTailnetRoutesConcurrency = 10
+
+// deviceRoutesResult is one worker's outcome for a single device in
+// TailnetRoutes.
+type deviceRoutesResult struct {
+	dr  DeviceRoutes
+	err error
+}
+
+// fetchDeviceRoutes fetches d's routes for use by a TailnetRoutes worker.
+// It recovers from a panic in c.Routes and reports it as an error so that
+// one device misbehaving can't take down the whole batch, preserving
+// TailnetRoutes' partial-failure guarantee.
+func fetchDeviceRoutes(c *Client, ctx context.Context, d *Device) (res deviceRoutesResult) {
+	defer func() {
+		if p := recover(); p != nil {
+			res = deviceRoutesResult{err: fmt.Errorf("device %s: panic: %v", d.ID, p)}
+		}
+	}()
+
+	rt, err := c.Routes(ctx, d.ID)
+	if err != nil {
+		return deviceRoutesResult{err: fmt.Errorf("device %s: %w", d.ID, err)}
+	}
+	return deviceRoutesResult{dr: DeviceRoutes{DeviceID: d.ID, Hostname: d.Hostname, Routes: *rt}}
+}
+
+// TailnetRoutes returns the routes of every device in the tailnet,
+// optionally filtered by opts. Unlike issuing one Client.Routes call per
+// device serially, it fetches them concurrently from a bounded worker
+// pool and honors ctx cancellation. A per-device failure does not abort
+// the call: the failing device is omitted from routes and its error is
+// joined into err, so callers still get whatever results succeeded.
+func (c *Client) TailnetRoutes(ctx context.Context, opts TailnetRoutesOptions) (routes []DeviceRoutes, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("tailscale.TailnetRoutes: %w", err)
+		}
+	}()
+
+	devices, err := c.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.MaxConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultTailnetRoutesConcurrency
+	}
+
+	work := make(chan *Device)
+	results := make(chan deviceRoutesResult)
+
+	var wg sync.WaitGroup
+	wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer wg.Done()
+			for d := range work {
+				results <- fetchDeviceRoutes(c, ctx, d)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(work)
+		for _, d := range devices {
+			select {
+			case work <- d:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var errs []error
+	for res := range results {
+		if res.err != nil {
+			errs = append(errs, res.err)
+			continue
+		}
+		if !matchesTailnetRoutesOptions(res.dr.Routes, opts) {
+			continue
+		}
+		routes = append(routes, res.dr)
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		errs = append(errs, ctxErr)
+	}
+
+	sort.Slice(routes, func(i, j int) bool { return deviceIDLess(routes[i].DeviceID, routes[j].DeviceID) })
+
+	return routes, multierr.New(errs...)
+}
+
+// RouteMutation describes an additive/subtractive change to apply to a
+// device's enabled routes via Client.UpdateRoutes, instead of replacing
+// the whole enabled set as Client.SetRoutes does.
+type RouteMutation struct {
+	// Enable lists prefixes to add to the enabled set.
+	Enable []netaddr.IPPrefix
+	// Disable lists prefixes to remove from the enabled set.
+	Disable []netaddr.IPPrefix
+	// RequireAdvertised, if true, rejects enabling any prefix the device
+	// does not currently advertise, returning ErrRouteNotAdvertised.
+	RequireAdvertised bool
+}
+
+// UpdateRoutes applies mut to deviceID's enabled routes instead of
+// replacing the whole enabled set: it fetches the device's current Routes,
+// merges in mut.Enable, removes mut.Disable, and POSTs the result. The
+// fetch and the POST are separate requests, so a change made by another
+// operator between them is overwritten. Use DiffRoutes to inspect what the
+// change would do before applying it.
+func (c *Client) UpdateRoutes(ctx context.Context, deviceID string, mut RouteMutation) (routes *Routes, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("tailscale.UpdateRoutes: %w", err)
+		}
+	}()
+
+	current, err := c.Routes(ctx, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mut.RequireAdvertised {
+		for _, p := range mut.Enable {
+			if !containsPrefix(current.AdvertisedRoutes, p) {
+				return nil, fmt.Errorf("%w: %s", ErrRouteNotAdvertised, p)
+			}
+		}
+	}
+
+	disable := make(map[netaddr.IPPrefix]bool, len(mut.Disable))
+	for _, p := range mut.Disable {
+		disable[p] = true
+	}
+
+	seen := make(map[netaddr.IPPrefix]bool, len(current.EnabledRoutes)+len(mut.Enable))
+	merged := make([]netaddr.IPPrefix, 0, len(current.EnabledRoutes)+len(mut.Enable))
+	for _, p := range current.EnabledRoutes {
+		if disable[p] || seen[p] {
+			continue
+		}
+		seen[p] = true
+		merged = append(merged, p)
+	}
+	for _, p := range mut.Enable {
+		if disable[p] || seen[p] {
+			continue
+		}
+		seen[p] = true
+		merged = append(merged, p)
+	}
+
+	return c.SetRoutes(ctx, deviceID, merged)
+}
+
+// RouteDiff describes the routes added and removed between two Routes
+// snapshots, as produced by DiffRoutes.
+type RouteDiff struct {
+	EnabledAdded      []netaddr.IPPrefix
+	EnabledRemoved    []netaddr.IPPrefix
+	AdvertisedAdded   []netaddr.IPPrefix
+	AdvertisedRemoved []netaddr.IPPrefix
+}
+
+// DiffRoutes reports the routes added and removed in going from before to
+// after. Either argument may be nil, which is treated as an empty Routes
+// so callers aren't required to have a prior snapshot (e.g. before a
+// device's first successful route fetch). It makes no network calls, so
+// callers can use it to log or dry-run a route change before applying it
+// with UpdateRoutes or SetRoutes.
+func DiffRoutes(before, after *Routes) RouteDiff {
+	if before == nil {
+		before = &Routes{}
+	}
+	if after == nil {
+		after = &Routes{}
+	}
+	return RouteDiff{
+		EnabledAdded:      prefixesAdded(before.EnabledRoutes, after.EnabledRoutes),
+		EnabledRemoved:    prefixesAdded(after.EnabledRoutes, before.EnabledRoutes),
+		AdvertisedAdded:   prefixesAdded(before.AdvertisedRoutes, after.AdvertisedRoutes),
+		AdvertisedRemoved: prefixesAdded(after.AdvertisedRoutes, before.AdvertisedRoutes),
+	}
+}
+
+// prefixesAdded returns the prefixes present in b but not in a.
+func prefixesAdded(a, b []netaddr.IPPrefix) []netaddr.IPPrefix {
+	var added []netaddr.IPPrefix
+	for _, p := range b {
+		if !containsPrefix(a, p) {
+			added = append(added, p)
+		}
+	}
+	return added
+}
+
+// RouteEventKind identifies which of a device's route lists changed in a
+// RouteEvent.
+type RouteEventKind int
+
+const (
+	// RouteEventAdvertised indicates a change to a device's advertised routes.
+	RouteEventAdvertised RouteEventKind = iota
+	// RouteEventEnabled indicates a change to a device's enabled routes.
+	RouteEventEnabled
+)
+
+func (k RouteEventKind) String() string {
+	switch k {
+	case RouteEventAdvertised:
+		return "Advertised"
+	case RouteEventEnabled:
+		return "Enabled"
+	default:
+		return "Unknown"
+	}
+}
+
+// RouteEvent reports a change in a device's advertised or enabled routes,
+// as observed by Client.WatchRoutes or Client.WatchTailnetRoutes.
+type RouteEvent struct {
+	DeviceID string
+	Kind     RouteEventKind
+	Added    []netip.Prefix
+	Removed  []netip.Prefix
+}
+
+// WatchRoutesOptions configures Client.WatchRoutes and
+// Client.WatchTailnetRoutes.
+type WatchRoutesOptions struct {
+	// PollInterval is how often to poll for changes. Zero or negative
+	// means defaultWatchRoutesPollInterval.
+	PollInterval time.Duration
+	// MinBackoff and MaxBackoff bound the exponential backoff applied
+	// after a failed poll. Zero or negative means
+	// defaultWatchRoutesMinBackoff and defaultWatchRoutesMaxBackoff,
+	// respectively.
+	MinBackoff, MaxBackoff time.Duration
+}
+
+const (
+	defaultWatchRoutesPollInterval = 30 * time.Second
+	defaultWatchRoutesMinBackoff   = time.Second
+	defaultWatchRoutesMaxBackoff   = 5 * time.Minute
+)
+
+func (o WatchRoutesOptions) withDefaults() WatchRoutesOptions {
+	if o.PollInterval <= 0 {
+		o.PollInterval = defaultWatchRoutesPollInterval
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = defaultWatchRoutesMinBackoff
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = defaultWatchRoutesMaxBackoff
+	}
+	return o
+}
+
+// WatchRoutes polls deviceID's routes and emits a RouteEvent on the
+// returned channel whenever its advertised or enabled routes change. The
+// public API has no push channel, so this is implemented as a polling
+// loop: it uses If-None-Match so a server that honors it can answer an
+// unchanged poll with 304 Not Modified, and it backs off exponentially
+// between opts.MinBackoff and opts.MaxBackoff whenever a poll errors. The
+// returned channel is closed when ctx is done.
+func (c *Client) WatchRoutes(ctx context.Context, deviceID string, opts WatchRoutesOptions) (<-chan RouteEvent, error) {
+	opts = opts.withDefaults()
+
+	last, etag, err := c.routesNetipWithETag(ctx, deviceID, "")
+	if err != nil {
+		return nil, fmt.Errorf("tailscale.WatchRoutes: %w", err)
+	}
+
+	events := make(chan RouteEvent)
+	go func() {
+		defer close(events)
+		backoff := opts.MinBackoff
+		t := time.NewTimer(opts.PollInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+
+			cur, newETag, err := c.routesNetipWithETag(ctx, deviceID, etag)
+			if err != nil {
+				backoff *= 2
+				if backoff > opts.MaxBackoff {
+					backoff = opts.MaxBackoff
+				}
+				t.Reset(backoff)
+				continue
+			}
+			backoff = opts.MinBackoff
+			t.Reset(opts.PollInterval)
+
+			if cur == nil {
+				// 304 Not Modified: nothing changed.
+				continue
+			}
+			etag = newETag
+			emitRouteDiffs(ctx, events, deviceID, last, cur)
+			last = cur
+		}
+	}()
+	return events, nil
+}
+
+// WatchTailnetRoutes is the tailnet-wide analog of WatchRoutes: it polls
+// every device in the tailnet and emits a RouteEvent whenever any
+// device's advertised or enabled routes change, including a removal-style
+// event (all previously-present routes reported as Removed) when a
+// device disappears from the tailnet entirely.
+func (c *Client) WatchTailnetRoutes(ctx context.Context, opts WatchRoutesOptions) (<-chan RouteEvent, error) {
+	opts = opts.withDefaults()
+
+	last, err := c.snapshotTailnetRoutes(ctx)
+	if err != nil && last.present == nil {
+		return nil, fmt.Errorf("tailscale.WatchTailnetRoutes: %w", err)
+	}
+
+	events := make(chan RouteEvent)
+	go func() {
+		defer close(events)
+		backoff := opts.MinBackoff
+		t := time.NewTimer(opts.PollInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+
+			cur, err := c.snapshotTailnetRoutes(ctx)
+			if cur.present == nil {
+				// Listing devices itself failed; nothing to diff
+				// against yet, so there is nothing partial to keep.
+				backoff *= 2
+				if backoff > opts.MaxBackoff {
+					backoff = opts.MaxBackoff
+				}
+				t.Reset(backoff)
+				continue
+			}
+			if err != nil {
+				// One or more devices failed to fetch this round. Still
+				// diff and emit for the devices that did succeed below,
+				// but back off before the next poll since the tailnet
+				// isn't fully healthy.
+				backoff *= 2
+				if backoff > opts.MaxBackoff {
+					backoff = opts.MaxBackoff
+				}
+				t.Reset(backoff)
+			} else {
+				backoff = opts.MinBackoff
+				t.Reset(opts.PollInterval)
+			}
+
+			merged := make(map[string]*RoutesV2, len(cur.present))
+			seen := make(map[string]bool, len(last.routes)+len(cur.present))
+			for deviceID := range last.routes {
+				seen[deviceID] = true
+			}
+			for deviceID := range cur.present {
+				seen[deviceID] = true
+			}
+
+			for deviceID := range seen {
+				oldRoutes := last.routes[deviceID]
+				if oldRoutes == nil {
+					oldRoutes = &RoutesV2{}
+				}
+
+				if !cur.present[deviceID] {
+					// The device no longer exists in the tailnet: report
+					// everything it used to advertise/enable as removed.
+					emitRouteDiffs(ctx, events, deviceID, oldRoutes, &RoutesV2{})
+					continue
+				}
+
+				curRoutes, fetched := cur.routes[deviceID]
+				if !fetched {
+					// The device still exists but its routes failed to
+					// fetch this round; carry its last known routes
+					// forward instead of reporting a spurious removal.
+					merged[deviceID] = last.routes[deviceID]
+					continue
+				}
+
+				emitRouteDiffs(ctx, events, deviceID, oldRoutes, curRoutes)
+				merged[deviceID] = curRoutes
+			}
+			last = tailnetRoutesSnapshot{routes: merged, present: cur.present}
+		}
+	}()
+	return events, nil
+}
+
+// tailnetRoutesSnapshot is one round of Client.snapshotTailnetRoutes: the
+// routes successfully fetched this round, and the full set of device IDs
+// known to be present in the tailnet this round (whether or not their
+// routes were fetched successfully).
+type tailnetRoutesSnapshot struct {
+	routes  map[string]*RoutesV2
+	present map[string]bool
+}
+
+// snapshotTailnetRoutes fetches the current RoutesV2 of every device in
+// the tailnet, keyed by device ID. Unlike Client.TailnetRoutes, it polls
+// devices serially since it is only ever called from WatchTailnetRoutes,
+// for its initial snapshot and from its background loop. A per-device fetch failure does not abort the call:
+// the device is still reported present, just without an entry in routes,
+// and its error is joined into err so the caller can still use whatever
+// succeeded.
+func (c *Client) snapshotTailnetRoutes(ctx context.Context) (tailnetRoutesSnapshot, error) {
+	devices, err := c.Devices(ctx)
+	if err != nil {
+		return tailnetRoutesSnapshot{}, err
+	}
+
+	snap := tailnetRoutesSnapshot{
+		routes:  make(map[string]*RoutesV2, len(devices)),
+		present: make(map[string]bool, len(devices)),
+	}
+	var errs []error
+	for _, d := range devices {
+		snap.present[d.ID] = true
+		rv, _, err := c.routesNetipWithETag(ctx, d.ID, "")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("device %s: %w", d.ID, err))
+			continue
+		}
+		snap.routes[d.ID] = rv
+	}
+	return snap, multierr.New(errs...)
+}
+
+// routesNetipWithETag fetches deviceID's routes like RoutesNetip, but
+// additionally sends an If-None-Match request header when etag is
+// non-empty. If the server responds 304 Not Modified, it returns a nil
+// *RoutesV2 and the unchanged etag instead of an error.
+func (c *Client) routesNetipWithETag(ctx context.Context, deviceID, etag string) (routes *RoutesV2, newETag string, err error) {
+	path := fmt.Sprintf("%s/api/v2/device/%s/routes", c.baseURL(), deviceID)
+	req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	b, resp, err := c.sendRequest(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, etag, nil
+	}
+	// If status code was not successful, return the error.
+	// TODO: Change the check for the StatusCode to include other 2XX success codes.
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", handleErrorResponse(b, resp)
+	}
+
+	var rv RoutesV2
+	if err := json.Unmarshal(b, &rv); err != nil {
+		return nil, "", err
+	}
+	return &rv, resp.Header.Get("ETag"), nil
+}
+
+// emitRouteDiffs sends a RouteEvent for each of the advertised and
+// enabled route lists that changed between old and cur, for deviceID. It
+// stops early without sending if ctx is done first.
+func emitRouteDiffs(ctx context.Context, events chan<- RouteEvent, deviceID string, old, cur *RoutesV2) {
+	if added, removed := netipPrefixDiff(old.AdvertisedRoutes, cur.AdvertisedRoutes); len(added) > 0 || len(removed) > 0 {
+		select {
+		case events <- RouteEvent{DeviceID: deviceID, Kind: RouteEventAdvertised, Added: added, Removed: removed}:
+		case <-ctx.Done():
+			return
+		}
+	}
+	if added, removed := netipPrefixDiff(old.EnabledRoutes, cur.EnabledRoutes); len(added) > 0 || len(removed) > 0 {
+		select {
+		case events <- RouteEvent{DeviceID: deviceID, Kind: RouteEventEnabled, Added: added, Removed: removed}:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// netipPrefixDiff reports the prefixes added and removed in going from
+// old to cur.
+func netipPrefixDiff(old, cur []netip.Prefix) (added, removed []netip.Prefix) {
+	for _, p := range cur {
+		if !containsNetipPrefix(old, p) {
+			added = append(added, p)
+		}
+	}
+	for _, p := range old {
+		if !containsNetipPrefix(cur, p) {
+			removed = append(removed, p)
+		}
+	}
+	return added, removed
+}
+
+func containsNetipPrefix(ps []netip.Prefix, p netip.Prefix) bool {
+	for _, q := range ps {
+		if q == p {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesTailnetRoutesOptions(r Routes, opts TailnetRoutesOptions) bool {
+	if opts.AdvertisedOnly && len(r.AdvertisedRoutes) == 0 {
+		return false
+	}
+	if opts.EnabledOnly && len(r.EnabledRoutes) == 0 {
+		return false
+	}
+	if opts.ExitNodesOnly && !r.AdvertisedExitNode() {
+		return false
+	}
+	if opts.ContainsPrefix != (netaddr.IPPrefix{}) {
+		if !containsPrefix(r.AdvertisedRoutes, opts.ContainsPrefix) && !containsPrefix(r.EnabledRoutes, opts.ContainsPrefix) {
+			return false
+		}
+	}
+	return true
+}
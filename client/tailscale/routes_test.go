@@ -0,0 +1,198 @@
+// Copyright (c) 2022 Tailscale Inc & AUTHORS All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package tailscale
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+
+	"inet.af/netaddr"
+)
+
+func mustIPPrefix(t *testing.T, s string) netaddr.IPPrefix {
+	t.Helper()
+	p, err := netaddr.ParseIPPrefix(s)
+	if err != nil {
+		t.Fatalf("ParseIPPrefix(%q): %v", s, err)
+	}
+	return p
+}
+
+func TestDiffRoutes(t *testing.T) {
+	p := func(s string) netaddr.IPPrefix { return mustIPPrefix(t, s) }
+
+	old := &Routes{
+		AdvertisedRoutes: []netaddr.IPPrefix{p("10.0.0.0/24"), p("10.0.1.0/24")},
+		EnabledRoutes:    []netaddr.IPPrefix{p("10.0.0.0/24")},
+	}
+	new := &Routes{
+		AdvertisedRoutes: []netaddr.IPPrefix{p("10.0.1.0/24"), p("10.0.2.0/24")},
+		EnabledRoutes:    []netaddr.IPPrefix{p("10.0.1.0/24")},
+	}
+
+	got := DiffRoutes(old, new)
+	want := RouteDiff{
+		EnabledAdded:      []netaddr.IPPrefix{p("10.0.1.0/24")},
+		EnabledRemoved:    []netaddr.IPPrefix{p("10.0.0.0/24")},
+		AdvertisedAdded:   []netaddr.IPPrefix{p("10.0.2.0/24")},
+		AdvertisedRemoved: []netaddr.IPPrefix{p("10.0.0.0/24")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffRoutes = %+v, want %+v", got, want)
+	}
+}
+
+func TestDiffRoutesNoChange(t *testing.T) {
+	p := func(s string) netaddr.IPPrefix { return mustIPPrefix(t, s) }
+
+	r := &Routes{
+		AdvertisedRoutes: []netaddr.IPPrefix{p("10.0.0.0/24")},
+		EnabledRoutes:    []netaddr.IPPrefix{p("10.0.0.0/24")},
+	}
+	got := DiffRoutes(r, r)
+	want := RouteDiff{}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffRoutes(r, r) = %+v, want zero value %+v", got, want)
+	}
+}
+
+func TestDiffRoutesNil(t *testing.T) {
+	p := func(s string) netaddr.IPPrefix { return mustIPPrefix(t, s) }
+
+	after := &Routes{
+		AdvertisedRoutes: []netaddr.IPPrefix{p("10.0.0.0/24")},
+		EnabledRoutes:    []netaddr.IPPrefix{p("10.0.0.0/24")},
+	}
+
+	got := DiffRoutes(nil, after)
+	want := RouteDiff{
+		EnabledAdded:    []netaddr.IPPrefix{p("10.0.0.0/24")},
+		AdvertisedAdded: []netaddr.IPPrefix{p("10.0.0.0/24")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffRoutes(nil, after) = %+v, want %+v", got, want)
+	}
+
+	got = DiffRoutes(after, nil)
+	want = RouteDiff{
+		EnabledRemoved:    []netaddr.IPPrefix{p("10.0.0.0/24")},
+		AdvertisedRemoved: []netaddr.IPPrefix{p("10.0.0.0/24")},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffRoutes(before, nil) = %+v, want %+v", got, want)
+	}
+
+	if got := DiffRoutes(nil, nil); !reflect.DeepEqual(got, RouteDiff{}) {
+		t.Errorf("DiffRoutes(nil, nil) = %+v, want zero value", got)
+	}
+}
+
+func TestMatchesTailnetRoutesOptions(t *testing.T) {
+	p := func(s string) netaddr.IPPrefix { return mustIPPrefix(t, s) }
+	exitNode := Routes{
+		AdvertisedRoutes: []netaddr.IPPrefix{exitNodeV4Route, exitNodeV6Route},
+		EnabledRoutes:    []netaddr.IPPrefix{exitNodeV4Route, exitNodeV6Route},
+	}
+	subnetOnly := Routes{
+		AdvertisedRoutes: []netaddr.IPPrefix{p("10.0.0.0/24")},
+	}
+	noRoutes := Routes{}
+
+	tests := []struct {
+		name string
+		r    Routes
+		opts TailnetRoutesOptions
+		want bool
+	}{
+		{"zero value matches everything", noRoutes, TailnetRoutesOptions{}, true},
+		{"advertised only excludes empty", noRoutes, TailnetRoutesOptions{AdvertisedOnly: true}, false},
+		{"advertised only includes subnet router", subnetOnly, TailnetRoutesOptions{AdvertisedOnly: true}, true},
+		{"enabled only excludes advertised-but-not-enabled", subnetOnly, TailnetRoutesOptions{EnabledOnly: true}, false},
+		{"exit nodes only excludes subnet router", subnetOnly, TailnetRoutesOptions{ExitNodesOnly: true}, false},
+		{"exit nodes only includes exit node", exitNode, TailnetRoutesOptions{ExitNodesOnly: true}, true},
+		{"contains prefix matches advertised", subnetOnly, TailnetRoutesOptions{ContainsPrefix: p("10.0.0.0/24")}, true},
+		{"contains prefix excludes non-matching", subnetOnly, TailnetRoutesOptions{ContainsPrefix: p("10.0.1.0/24")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesTailnetRoutesOptions(tt.r, tt.opts); got != tt.want {
+				t.Errorf("matchesTailnetRoutesOptions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetExitNodeMismatch(t *testing.T) {
+	// The enableV4 != enableV6 check runs before c touches the network,
+	// so a nil *Client is enough to exercise it.
+	var c *Client
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		enableV4, enableV6 bool
+	}{
+		{true, false},
+		{false, true},
+	} {
+		_, err := c.SetExitNode(ctx, "device", tt.enableV4, tt.enableV6)
+		if !errors.Is(err, ErrExitNodeMismatch) {
+			t.Errorf("SetExitNode(%v, %v) error = %v, want ErrExitNodeMismatch", tt.enableV4, tt.enableV6, err)
+		}
+	}
+}
+
+func TestDeviceIDLess(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"2", "10", true},
+		{"10", "2", false},
+		{"2", "2", false},
+		{"9", "10", true},
+		{"113962345", "99", false},
+		{"99", "113962345", true},
+		{"1", "1", false},
+	}
+	for _, tt := range tests {
+		if got := deviceIDLess(tt.a, tt.b); got != tt.want {
+			t.Errorf("deviceIDLess(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNetipPrefixDiff(t *testing.T) {
+	p := func(s string) netip.Prefix { return netip.MustParsePrefix(s) }
+
+	old := []netip.Prefix{p("10.0.0.0/24"), p("10.0.1.0/24")}
+	cur := []netip.Prefix{p("10.0.1.0/24"), p("10.0.2.0/24")}
+
+	added, removed := netipPrefixDiff(old, cur)
+	if want := []netip.Prefix{p("10.0.2.0/24")}; !reflect.DeepEqual(added, want) {
+		t.Errorf("added = %v, want %v", added, want)
+	}
+	if want := []netip.Prefix{p("10.0.0.0/24")}; !reflect.DeepEqual(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+}
+
+func TestNetipPrefixDiffNoChange(t *testing.T) {
+	p := func(s string) netip.Prefix { return netip.MustParsePrefix(s) }
+
+	ps := []netip.Prefix{p("10.0.0.0/24")}
+	added, removed := netipPrefixDiff(ps, ps)
+	if added != nil {
+		t.Errorf("added = %v, want nil", added)
+	}
+	if removed != nil {
+		t.Errorf("removed = %v, want nil", removed)
+	}
+}